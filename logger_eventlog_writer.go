@@ -0,0 +1,64 @@
+//go:build windows
+// +build windows
+
+package gin
+
+import (
+	"strings"
+
+	"golang.org/x/sys/windows/svc/eventlog"
+)
+
+// EventlogWriter ships JSON log records to the Windows event log,
+// translating the record level to the nearest eventlog severity.
+type EventlogWriter struct {
+	Source string
+
+	log *eventlog.Log
+}
+
+// NewEventlogWriter opens (installing if necessary) the named event source.
+func NewEventlogWriter(source string) (*EventlogWriter, error) {
+	if err := eventlog.InstallAsEventCreate(source, eventlog.Error|eventlog.Warning|eventlog.Info); err != nil {
+		// Ignore "already exists" so repeated startups don't fail.
+		if !isEventSourceExists(err) {
+			return nil, err
+		}
+	}
+	l, err := eventlog.Open(source)
+	if err != nil {
+		return nil, err
+	}
+	return &EventlogWriter{Source: source, log: l}, nil
+}
+
+// Write implements io.Writer, reporting p to the Windows event log at the
+// severity matching its JSON "level" field.
+func (w *EventlogWriter) Write(p []byte) (int, error) {
+	msg := string(p)
+	switch severityFromLevel(parseRecordLevel(p)) {
+	case 0, 1, 2, 3:
+		if err := w.log.Error(1, msg); err != nil {
+			return 0, err
+		}
+	case 4:
+		if err := w.log.Warning(1, msg); err != nil {
+			return 0, err
+		}
+	default:
+		if err := w.log.Info(1, msg); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Close closes the underlying event log handle.
+func (w *EventlogWriter) Close() error {
+	return w.log.Close()
+}
+
+func isEventSourceExists(err error) bool {
+	return err != nil && (err.Error() == "Use of Reg.exe in WoW64 is not supported" ||
+		strings.HasSuffix(err.Error(), "registry key already exists"))
+}
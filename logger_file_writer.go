@@ -0,0 +1,321 @@
+package gin
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileWriterConfig configures a FileWriter. It holds no mutex, so it can be
+// passed to NewFileWriter (and copied) freely.
+type FileWriterConfig struct {
+	// Dir is the directory the log file and its rotated backups live in.
+	Dir string
+
+	// Name is the active log file name, e.g. "app.log".
+	Name string
+
+	// LogLimitSize is the size-based rotation threshold, e.g. "1G", "512MB", "100KB".
+	// Optional. Default "1G".
+	LogLimitSize string
+
+	// RotateInterval rotates the file on a fixed schedule (e.g. 24h for
+	// daily) regardless of size. Optional: zero disables time rotation.
+	RotateInterval time.Duration
+
+	// Gzip compresses rotated files in the background.
+	Gzip bool
+
+	// MaxBackups is the maximum number of rotated files to keep, oldest
+	// first. Optional: zero disables the count-based limit.
+	MaxBackups int
+
+	// LogExpDays is the maximum age, in days, a rotated file is kept.
+	// Optional. Default 30.
+	LogExpDays int64
+}
+
+// FileWriter is an io.WriteCloser that writes to a rotating log file. It
+// rotates by size and/or on a fixed time interval, renaming the active file
+// with a templated name (base.timestamp.host.pid.ext) before atomically
+// reopening a fresh one, optionally gzips rotated files in the background,
+// and prunes old backups by count and/or age. Unlike the package-level
+// logger it owns its own state, so multiple gin engines can each run an
+// independent FileWriter without stepping on one another.
+type FileWriter struct {
+	dir  string
+	name string
+
+	rotateInterval time.Duration
+	gzip           bool
+	maxBackups     int
+	logExpDays     int64
+
+	mu         sync.Mutex
+	file       *os.File
+	size       int64
+	limitBytes int64
+	rotateAt   time.Time
+	hostname   string
+	pid        int
+
+	pruneDone chan struct{}
+}
+
+// NewFileWriter creates a FileWriter from conf, opening (or creating) the
+// active log file and starting its background prune loop.
+func NewFileWriter(conf FileWriterConfig) (*FileWriter, error) {
+	if conf.LogLimitSize == "" {
+		conf.LogLimitSize = "1G"
+	}
+	limitBytes, err := parseLogLimitSize(conf.LogLimitSize)
+	if err != nil {
+		return nil, err
+	}
+	if conf.LogExpDays == 0 {
+		conf.LogExpDays = 30
+	}
+
+	hostname, _ := os.Hostname()
+	fw := &FileWriter{
+		dir:            conf.Dir,
+		name:           conf.Name,
+		rotateInterval: conf.RotateInterval,
+		gzip:           conf.Gzip,
+		maxBackups:     conf.MaxBackups,
+		logExpDays:     conf.LogExpDays,
+		limitBytes:     limitBytes,
+		hostname:       hostname,
+		pid:            os.Getpid(),
+		pruneDone:      make(chan struct{}),
+	}
+
+	if err := fw.openCurrent(); err != nil {
+		return nil, err
+	}
+	if fw.rotateInterval > 0 {
+		fw.rotateAt = time.Now().Add(fw.rotateInterval)
+	}
+
+	go fw.pruneLoop()
+
+	return fw, nil
+}
+
+// parseLogLimitSize parses a size string like "1G", "512MB", or "100KB"
+// into bytes.
+func parseLogLimitSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	units := []struct {
+		suffix string
+		mult   int64
+	}{
+		{"GB", 1024 * 1024 * 1024},
+		{"G", 1024 * 1024 * 1024},
+		{"MB", 1024 * 1024},
+		{"KB", 1024},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(s, u.suffix) {
+			n, err := strconv.ParseInt(strings.TrimSuffix(s, u.suffix), 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid log limit size %q: %w", s, err)
+			}
+			return n * u.mult, nil
+		}
+	}
+	return 0, fmt.Errorf("invalid log limit size %q: unrecognized unit", s)
+}
+
+func (fw *FileWriter) path() string {
+	return filepath.Join(fw.dir, fw.name)
+}
+
+// Write implements io.Writer, rotating first if the active file has grown
+// past its size limit or its scheduled rotation time has passed.
+func (fw *FileWriter) Write(p []byte) (int, error) {
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+
+	if fw.shouldRotateLocked() {
+		if err := fw.rotateLocked(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := fw.file.Write(p)
+	fw.size += int64(n)
+	return n, err
+}
+
+// Close closes the active log file and stops the background prune loop.
+func (fw *FileWriter) Close() error {
+	close(fw.pruneDone)
+	fw.mu.Lock()
+	defer fw.mu.Unlock()
+	return fw.file.Close()
+}
+
+func (fw *FileWriter) shouldRotateLocked() bool {
+	if fw.size >= fw.limitBytes {
+		return true
+	}
+	if fw.rotateInterval > 0 && !fw.rotateAt.IsZero() && !time.Now().Before(fw.rotateAt) {
+		return true
+	}
+	return false
+}
+
+// rotateLocked atomically closes the active file, renames it to its
+// templated backup name, and reopens a fresh active file, so writers never
+// lose bytes across rotation.
+func (fw *FileWriter) rotateLocked() error {
+	if err := fw.file.Close(); err != nil {
+		return err
+	}
+
+	backup := fw.rotatedPath()
+	if err := os.Rename(fw.path(), backup); err != nil {
+		// The rename failed, so the original file is still at fw.path();
+		// reopen it so writes can continue.
+		return fw.openCurrentLocked()
+	}
+
+	if fw.gzip {
+		go gzipFile(backup)
+	}
+	if fw.rotateInterval > 0 {
+		fw.rotateAt = time.Now().Add(fw.rotateInterval)
+	}
+
+	return fw.openCurrentLocked()
+}
+
+// rotatedPath builds the templated backup name:
+// base.2006-01-02T15-04-05.host.pid.ext
+func (fw *FileWriter) rotatedPath() string {
+	ext := filepath.Ext(fw.name)
+	base := strings.TrimSuffix(fw.name, ext)
+	ts := time.Now().Format("2006-01-02T15-04-05")
+	name := fmt.Sprintf("%s.%s.%s.%d%s", base, ts, fw.hostname, fw.pid, ext)
+	return filepath.Join(fw.dir, name)
+}
+
+func (fw *FileWriter) openCurrent() error {
+	if err := os.MkdirAll(fw.dir, 0755); err != nil {
+		return err
+	}
+	return fw.openCurrentLocked()
+}
+
+func (fw *FileWriter) openCurrentLocked() error {
+	f, err := os.OpenFile(fw.path(), os.O_CREATE|os.O_APPEND|os.O_RDWR, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	fw.file = f
+	fw.size = info.Size()
+	fw.updateSymlink()
+	return nil
+}
+
+// updateSymlink points dir/current at the active log file.
+func (fw *FileWriter) updateSymlink() {
+	link := filepath.Join(fw.dir, "current")
+	_ = os.Remove(link)
+	_ = os.Symlink(fw.name, link)
+}
+
+func gzipFile(path string) {
+	in, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return
+	}
+	defer out.Close()
+
+	gz := gzip.NewWriter(out)
+	if _, err := io.Copy(gz, in); err != nil {
+		gz.Close()
+		return
+	}
+	if err := gz.Close(); err != nil {
+		return
+	}
+	_ = os.Remove(path)
+}
+
+func (fw *FileWriter) pruneLoop() {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			fw.prune()
+		case <-fw.pruneDone:
+			return
+		}
+	}
+}
+
+// prune removes rotated backups older than logExpDays and, once maxBackups
+// is set, the oldest backups beyond that count.
+func (fw *FileWriter) prune() {
+	files, err := ioutil.ReadDir(fw.dir)
+	if err != nil {
+		return
+	}
+
+	ext := filepath.Ext(fw.name)
+	base := strings.TrimSuffix(fw.name, ext)
+
+	var backups []os.FileInfo
+	for _, f := range files {
+		if f.IsDir() || f.Name() == fw.name {
+			continue
+		}
+		if !strings.HasPrefix(f.Name(), base+".") {
+			continue
+		}
+		backups = append(backups, f)
+	}
+
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().Before(backups[j].ModTime())
+	})
+
+	cutoff := time.Now().Add(-time.Duration(fw.logExpDays) * 24 * time.Hour)
+	kept := backups[:0]
+	for _, f := range backups {
+		if f.ModTime().Before(cutoff) {
+			_ = os.Remove(filepath.Join(fw.dir, f.Name()))
+			continue
+		}
+		kept = append(kept, f)
+	}
+
+	if fw.maxBackups > 0 && len(kept) > fw.maxBackups {
+		excess := len(kept) - fw.maxBackups
+		for _, f := range kept[:excess] {
+			_ = os.Remove(filepath.Join(fw.dir, f.Name()))
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package gin
+
+import "testing"
+
+func TestParseLogLimitSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"1G", 1 << 30, false},
+		{"2GB", 2 << 30, false},
+		{"512MB", 512 << 20, false},
+		{"100KB", 100 << 10, false},
+		{"", 0, true},
+		{"1TB", 0, true},
+		{"xMB", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseLogLimitSize(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseLogLimitSize(%q): want error, got nil", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseLogLimitSize(%q): unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseLogLimitSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
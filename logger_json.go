@@ -1,20 +1,14 @@
 package gin
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"github.com/google/uuid"
-	"github.com/ptechen/encoding"
-	"github.com/robfig/cron/v3"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/diode"
 	"github.com/rs/zerolog/log"
 	"io"
-	"io/ioutil"
 	"os"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
 )
 
@@ -59,6 +53,30 @@ type JsonLoggerConfig struct {
 	// Optional. Default value is gin.DefaultWriter.
 	Output io.Writer
 
+	// Remote ships every log record to an HTTP collector in addition to
+	// Output. Optional. When nil, logs are only written locally.
+	Remote *RemoteWriterConfig
+
+	// Sinks routes each log record to the writers whose level range
+	// contains it, replacing Output and Remote when set: once Sinks is
+	// non-empty, Output and Remote are no longer written to directly (a
+	// warning is logged to that effect). Callers who still want local
+	// file output and/or remote shipping alongside Sinks should include a
+	// FileWriter/RemoteWriter as the Writer of one of the LevelSink
+	// entries (typically one covering the full level range). Optional.
+	Sinks []LevelSink
+
+	// Propagator extracts/injects distributed-tracing context from/into
+	// request headers. Optional: when nil, W3C traceparent headers are
+	// parsed and emitted directly.
+	Propagator Propagator
+
+	// SamplePolicy maps a request path to a Sampler deciding whether that
+	// path's request log line is emitted. Optional: paths without an
+	// entry are always logged. Suppressed lines are tallied and reported
+	// periodically as a sampled_dropped summary record.
+	SamplePolicy map[string]Sampler
+
 	// SkipPaths is a url path array which logs are not written.
 	// Optional.
 	SkipPaths []string
@@ -87,13 +105,28 @@ type JsonLoggerConfig struct {
 	// LogLimitSize is the limit size of the log file, for example 1G and 512MB.
 	LogLimitSize string
 
+	// RotateInterval additionally rotates the log file on a fixed schedule
+	// (e.g. 24*time.Hour for daily), regardless of size. Optional.
+	RotateInterval time.Duration
+
+	// Gzip compresses rotated log files in the background.
+	Gzip bool
+
+	// MaxBackups is the maximum number of rotated log files to keep, in
+	// addition to LogExpDays. Optional: zero disables the count-based limit.
+	MaxBackups int
+
 	logFilePath string
 
 	logDir string
 
 	logName string
 
-	logLimitNums int64
+	fileWriter *FileWriter
+
+	remoteWriter *RemoteWriter
+
+	sampleDropped *sampledDroppedTracker
 }
 
 // JsonLogger instances a Logger middleware that will write the logs to gin.DefaultWriter.
@@ -105,16 +138,7 @@ func JsonLogger() HandlerFunc {
 	})
 }
 
-var once sync.Once
 var logger *zerolog.Logger
-var skip map[string]struct{}
-
-type TraceParams struct {
-	StartTime time.Time
-	Path      string
-	ClientIp  string
-	Method    string
-}
 
 // JsonLoggerWithWriter instance a Logger middleware with the specified writer buffer.
 // Example: os.Stdout, a file opened in write mode, a socket...
@@ -128,36 +152,26 @@ func JsonLoggerWithConfig(conf JsonLoggerConfig) HandlerFunc {
 		conf.Output = DefaultWriter
 	}
 
-	once.Do(func() {
-		conf.InitLogConfig()
+	conf.InitLogConfig()
 
-		conf.Monitor()
-
-		notLogged := conf.SkipPaths
-		length := len(notLogged)
-		if length > 0 {
-			skip = make(map[string]struct{}, length)
-			for i := 0; i < length; i++ {
-				skip[notLogged[i]] = struct{}{}
-			}
-		}
-	})
+	notLogged := conf.SkipPaths
+	skip := make(map[string]struct{}, len(notLogged))
+	for i := 0; i < len(notLogged); i++ {
+		skip[notLogged[i]] = struct{}{}
+	}
 
 	return func(c *Context) {
 		// Start timer
 		start := time.Now()
 		path := c.Request.URL.Path
 
-		params := &TraceParams{
-			StartTime: start,
-			Path:      c.Request.URL.String(),
-			ClientIp:  c.ClientIP(),
-			Method:    c.Request.Method,
-		}
+		tc := conf.extractTrace(c.Request.Header)
+		conf.injectTrace(c.Writer.Header(), tc)
 
-		traceId, _ := createUuid(params)
 		c.Logger = log.With().
-			Str("trace_id", traceId).
+			Str("trace_id", tc.TraceID).
+			Str("span_id", tc.SpanID).
+			Str("parent_span_id", tc.ParentSpanID).
 			Str("path", c.Request.URL.String()).
 			Str("client_ip", c.ClientIP()).
 			Str("method", c.Request.Method).
@@ -181,9 +195,15 @@ func JsonLoggerWithConfig(conf JsonLoggerConfig) HandlerFunc {
 			param.StatusCode = c.Writer.Status()
 			param.ErrorMessage = c.Errors.ByType(ErrorTypePrivate).String()
 			if param.ErrorMessage == "" {
-				c.Logger.Info().Dur("latency", param.Latency).
-					Int("status", param.StatusCode).
-					Interface("keys", c.Keys).Send()
+				if sampler, ok := conf.SamplePolicy[path]; ok && !sampler.Sample() {
+					if conf.sampleDropped != nil {
+						conf.sampleDropped.record(path)
+					}
+				} else {
+					c.Logger.Info().Dur("latency", param.Latency).
+						Int("status", param.StatusCode).
+						Interface("keys", c.Keys).Send()
+				}
 			} else {
 				c.Logger.Err(errors.New(param.ErrorMessage)).
 					Dur("latency", param.Latency).
@@ -199,12 +219,16 @@ func (p *JsonLoggerConfig) InitLogConfig() {
 	logger = &log.Logger
 	zerolog.TimeFieldFormat = p.LogTimeFieldFormat
 	p.SetFilePath2FileName()
-	p.SetLogFileSize()
+	if err := p.SetupFileWriter(); err != nil {
+		logger.Warn().Err(err).Msg("Logger failed to set up FileWriter")
+	}
 	p.SetLoglevel()
-	p.CheckLogExpDays()
 	p.setCaller()
 	p.CheckLogWriteSize()
 	p.SetOutput()
+	if len(p.SamplePolicy) > 0 {
+		p.sampleDropped = newSampledDroppedTracker()
+	}
 }
 
 func (p *JsonLoggerConfig) setCaller() {
@@ -213,9 +237,28 @@ func (p *JsonLoggerConfig) setCaller() {
 	}
 }
 
-func (p *JsonLoggerConfig) ReCreateLogFile() {
-	f, _ := os.OpenFile(p.logFilePath, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0666)
-	p.Output = f
+// SetupFileWriter replaces Output with a FileWriter handling size/time
+// rotation, gzip, and pruning, when Output was a plain *os.File.
+func (p *JsonLoggerConfig) SetupFileWriter() error {
+	if p.logFilePath == "" {
+		return nil
+	}
+
+	fw, err := NewFileWriter(FileWriterConfig{
+		Dir:            p.logDir,
+		Name:           p.logName,
+		LogLimitSize:   p.LogLimitSize,
+		RotateInterval: p.RotateInterval,
+		Gzip:           p.Gzip,
+		MaxBackups:     p.MaxBackups,
+		LogExpDays:     p.LogExpDays,
+	})
+	if err != nil {
+		return err
+	}
+	p.fileWriter = fw
+	p.Output = fw
+	return nil
 }
 
 // SetOutput is a method to set the log output path.
@@ -224,19 +267,62 @@ func (p *JsonLoggerConfig) SetOutput() {
 		p.Output = zerolog.ConsoleWriter{Out: p.Output}
 	}
 
-	w := diode.NewWriter(p.Output, p.LogWriteSize, 10*time.Millisecond, func(missed int) {
+	out := p.Output
+	if p.Remote != nil {
+		rw, err := NewRemoteWriter(*p.Remote, p.logDir)
+		if err != nil {
+			logger.Warn().Err(err).Msg("Logger failed to start RemoteWriter")
+		} else {
+			p.remoteWriter = rw
+			out = io.MultiWriter(p.Output, rw)
+		}
+	}
+
+	if len(p.Sinks) > 0 {
+		logger.Warn().Msg("Logger Sinks configured: Output and Remote are bypassed; " +
+			"add a FileWriter/RemoteWriter as a LevelSink entry to keep writing to them")
+		out = newSinkFanout(p.Sinks)
+	}
+
+	w := diode.NewWriter(out, p.LogWriteSize, 10*time.Millisecond, func(missed int) {
 		logger.Warn().Msgf("Logger Dropped %d messages", missed)
 	})
 
 	*logger = logger.Output(w)
 }
 
+// Shutdown closes the FileWriter (stopping its prune loop), stops the
+// sampled_dropped reporting loop, and flushes and stops the RemoteWriter,
+// if configured. The diode writer's polling goroutine started by SetOutput
+// and any WatchSIGHUP goroutine are not stopped.
+func (p *JsonLoggerConfig) Shutdown(ctx context.Context) error {
+	var errs []error
+
+	if p.fileWriter != nil {
+		if err := p.fileWriter.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if p.sampleDropped != nil {
+		p.sampleDropped.Close()
+	}
+
+	if p.remoteWriter != nil {
+		if err := p.remoteWriter.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
 // SetLoglevel is a method to set the alarm level for checking logs.
 func (p *JsonLoggerConfig) SetLoglevel() {
 	if p.LogLevel < -1 || p.LogLevel > 7 {
 		p.LogLevel = 0
 	}
-	*logger = logger.Level(zerolog.Level(p.LogLevel))
+	currentLevel.Set(p.LogLevel)
 }
 
 // CheckLogWriteSize is a method to set the default log write channel size.
@@ -246,33 +332,6 @@ func (p *JsonLoggerConfig) CheckLogWriteSize() {
 	}
 }
 
-// SetLogFileSize is a method for setting a limit on the size of a log file.
-func (p *JsonLoggerConfig) SetLogFileSize() {
-	if !strings.Contains(p.LogLimitSize, "G") &&
-		!strings.Contains(p.LogLimitSize, "MB") &&
-		!strings.Contains(p.LogLimitSize, "KB") {
-		p.LogLimitSize = "1G"
-	}
-
-	if strings.Contains(p.LogLimitSize, "G") {
-		n, _ := strconv.Atoi(strings.Split(p.LogLimitSize, "G")[0])
-		p.logLimitNums = int64(n) * 1024 * 1024 * 1024
-	} else if strings.Contains(p.LogLimitSize, "KB") {
-		n, _ := strconv.Atoi(strings.Split(p.LogLimitSize, "MB")[0])
-		p.logLimitNums = int64(n) * 1024 * 1024
-	} else {
-		n, _ := strconv.Atoi(strings.Split(p.LogLimitSize, "KB")[0])
-		p.logLimitNums = int64(n) * 1024
-	}
-}
-
-// CheckLogExpDays is a method to check if the log file has an expiration time set.
-func (p *JsonLoggerConfig) CheckLogExpDays() {
-	if p.LogExpDays == 0 {
-		p.LogExpDays = 30
-	}
-}
-
 // SetFilePath2FileName is a method for the path and name of the log file.
 func (p *JsonLoggerConfig) SetFilePath2FileName() {
 	data, ok := p.Output.(*os.File)
@@ -293,87 +352,3 @@ func parseFileInfo(fileInfo string) (logDir, logName string) {
 	}
 	return
 }
-
-func (p *JsonLoggerConfig) tab1() {
-	logger.Info().Msg("tab1")
-	isExist := p.IsExist()
-	if !isExist {
-		p.SetOutput()
-	}
-	size := p.CheckFileSize()
-	if size > p.logLimitNums {
-		p.Rename2File()
-		p.ReCreateLogFile()
-		p.SetOutput()
-	}
-}
-
-// Monitor is a method of monitoring log files.
-func (p *JsonLoggerConfig) Monitor() {
-	if p.logFilePath == "" || p.logName == "" {
-		return
-	}
-	cronTab := cron.New()
-	cronTab.AddFunc("*/5 * * * * ?", p.tab1)
-	cronTab.AddFunc("0 0 1 * * ?", p.DeleteLogFile)
-	cronTab.Start()
-}
-
-// IsExist is a method to check if the log file exists.
-func (p *JsonLoggerConfig) IsExist() bool {
-	_, err := os.Stat(p.logFilePath)
-	return err == nil || os.IsExist(err)
-}
-
-// CheckFileSize is a method for checking the size of a log file.
-func (p *JsonLoggerConfig) CheckFileSize() int64 {
-	f, e := os.Stat(p.logFilePath)
-	if e != nil {
-		return 0
-	}
-	return f.Size()
-}
-
-// Rename2File is a method for renaming log files.
-func (p *JsonLoggerConfig) Rename2File() (newLogFileName string) {
-	now := time.Now()
-	newLogFileName = fmt.Sprintf("%s.%s", p.logFilePath, now.Format("2006-01-02 15:04:05"))
-	err := os.Rename(p.logFilePath, newLogFileName)
-	if err != nil {
-		return ""
-	}
-	return
-}
-
-// DeleteLogFile is a method for deleting log files.
-func (p *JsonLoggerConfig) DeleteLogFile() {
-	logger.Info().Msg("tab1")
-	files, _ := ioutil.ReadDir(p.logDir)
-	for _, file := range files {
-		if !file.IsDir() {
-			if file.Name() != p.logName && strings.Contains(file.Name(), p.logName) {
-				createTime := strings.Split(file.Name(), p.logName+".")[1]
-				date, err := time.Parse("2006-01-02 15:04:05", createTime)
-				if err != nil {
-					continue
-				}
-				dateUnix := date.Unix()
-				currentUnix := time.Now().Unix()
-				if currentUnix-dateUnix > p.LogExpDays*60*60*24 {
-					currentFileName := p.logDir + file.Name()
-					_ = os.Remove(currentFileName)
-				}
-			}
-		}
-	}
-}
-
-// CreateUuid is the method used to generate the tracking id.
-func createUuid(params interface{}) (uuidStr string, err error) {
-	data, err := encoding.JSON.Marshal(params)
-	if err != nil {
-		return uuidStr, err
-	}
-	uuidStr = uuid.NewMD5(uuid.UUID{}, data).String()
-	return uuidStr, err
-}
@@ -0,0 +1,96 @@
+package gin
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"github.com/rs/zerolog"
+)
+
+// AtomicLevel is a log level that can be read and updated concurrently,
+// replacing the fixed LogLevel so operators can raise or lower verbosity at
+// runtime without restarting the process.
+type AtomicLevel struct {
+	v atomic.Int32
+}
+
+// NewAtomicLevel creates an AtomicLevel set to level.
+func NewAtomicLevel(level int8) *AtomicLevel {
+	al := &AtomicLevel{}
+	al.Set(level)
+	return al
+}
+
+// Get returns the current level.
+func (al *AtomicLevel) Get() int8 {
+	return int8(al.v.Load())
+}
+
+// Set updates the current level and applies it to the package logger.
+func (al *AtomicLevel) Set(level int8) {
+	al.v.Store(int32(level))
+	if logger != nil {
+		*logger = logger.Level(zerolog.Level(level))
+	}
+}
+
+// currentLevel backs every JsonLoggerConfig's LevelHandler/WatchSIGHUP,
+// alongside the shared package-level logger they all mutate.
+var currentLevel = NewAtomicLevel(0)
+
+type levelRequestBody struct {
+	Level string `json:"level"`
+}
+
+// LevelHandler returns an http.HandlerFunc serving GET to read and PUT to
+// update the current log level as JSON ({"level":"info"}). Meant to be
+// wired up at a path like /debug/loglevel.
+func (p *JsonLoggerConfig) LevelHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeLevelJSON(w, currentLevel.Get())
+		case http.MethodPut:
+			var body levelRequestBody
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			lvl, err := zerolog.ParseLevel(body.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			currentLevel.Set(int8(lvl))
+			writeLevelJSON(w, currentLevel.Get())
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func writeLevelJSON(w http.ResponseWriter, level int8) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(levelRequestBody{Level: zerolog.Level(level).String()})
+}
+
+// WatchSIGHUP starts a background goroutine that, on SIGHUP, reloads the
+// log level from the named environment variable (e.g. "LOG_LEVEL").
+func (p *JsonLoggerConfig) WatchSIGHUP(envVar string) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGHUP)
+	go func() {
+		for range ch {
+			lvl, err := zerolog.ParseLevel(os.Getenv(envVar))
+			if err != nil {
+				logger.Warn().Err(err).Str("env", envVar).Msg("Logger failed to reload level on SIGHUP")
+				continue
+			}
+			currentLevel.Set(int8(lvl))
+		}
+	}()
+}
@@ -0,0 +1,341 @@
+package gin
+
+import (
+	"bytes"
+	"context"
+	crand "crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	mrand "math/rand"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// defaultFlushDelay is how long the RemoteWriter waits between flushes
+	// when the buffer isn't already over the flush threshold.
+	defaultFlushDelay = 2 * time.Second
+
+	// defaultMaxBufferBytes is the default ring buffer cap, halved (and
+	// halved again) under LowMemory.
+	defaultMaxBufferBytes = 4 << 20 // 4 MiB
+
+	// maxMessageSize and maxTextSize bound a single record before it is
+	// buffered; LowMemory quarters both.
+	maxMessageSize = 256 << 10
+	maxTextSize    = 16 << 10
+
+	minRemoteBackoff = 30 * time.Second
+	maxRemoteBackoff = 30 * time.Minute
+
+	privateIDFileName = ".remote_private_id"
+)
+
+// RemoteWriterConfig defines the config for shipping JSON log records to a
+// remote HTTP collector, modeled after Tailscale's logtail client.
+type RemoteWriterConfig struct {
+	// BaseURL is the collector endpoint records are POSTed to.
+	BaseURL string
+
+	// Collection identifies the log stream to the collector.
+	Collection string
+
+	// PrivateID identifies this writer to the collector. Optional: when
+	// empty, a random 32-byte hex id is generated once and persisted
+	// alongside the log directory.
+	PrivateID string
+
+	// FlushDelay is the interval between background flushes.
+	// Optional. Default value is 2s.
+	FlushDelay time.Duration
+
+	// MaxBufferBytes is the hard cap on buffered bytes; the oldest
+	// records are dropped once it is exceeded.
+	// Optional. Default value is 4 MiB (quartered under LowMemory).
+	MaxBufferBytes int
+
+	// LowMemory quarters the default MaxBufferBytes and the per-message
+	// size caps. An explicitly set MaxBufferBytes is used as given.
+	LowMemory bool
+
+	// HTTPClient is used to POST batches. Optional. Default is http.DefaultClient.
+	HTTPClient *http.Client
+
+	// DisableCompress turns off zstd compression of the POST body.
+	// Compression is enabled by default.
+	DisableCompress bool
+}
+
+// RemoteWriter is an io.Writer that buffers JSON log records in memory and
+// ships them in batches to Config.BaseURL, retrying with exponential
+// backoff on failure.
+type RemoteWriter struct {
+	conf      RemoteWriterConfig
+	privateID string
+
+	mu      sync.Mutex
+	buf     [][]byte
+	bufSize int
+	dropped int64
+
+	flushNow chan struct{}
+	closed   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewRemoteWriter creates a RemoteWriter and starts its background flush
+// loop. logDir is where the persisted PrivateID is stored when
+// conf.PrivateID is empty.
+func NewRemoteWriter(conf RemoteWriterConfig, logDir string) (*RemoteWriter, error) {
+	if conf.FlushDelay <= 0 {
+		conf.FlushDelay = defaultFlushDelay
+	}
+	if conf.HTTPClient == nil {
+		conf.HTTPClient = http.DefaultClient
+	}
+
+	maxBuf := defaultMaxBufferBytes
+	if conf.LowMemory {
+		maxBuf /= 4
+	}
+	if conf.MaxBufferBytes > 0 {
+		maxBuf = conf.MaxBufferBytes
+	}
+	conf.MaxBufferBytes = maxBuf
+
+	privateID := conf.PrivateID
+	if privateID == "" {
+		id, err := loadOrCreatePrivateID(logDir)
+		if err != nil {
+			return nil, err
+		}
+		privateID = id
+	}
+
+	w := &RemoteWriter{
+		conf:      conf,
+		privateID: privateID,
+		flushNow:  make(chan struct{}, 1),
+		closed:    make(chan struct{}),
+	}
+	w.wg.Add(1)
+	go w.loop()
+	return w, nil
+}
+
+// Write implements io.Writer. p is expected to be a single log record
+// (SetOutput always passes the raw JSON record, even when Output is wrapped
+// in a ConsoleWriter); a record that does not look like JSON gets the
+// tighter text cap. p is truncated to the configured per-message cap,
+// copied into the ring buffer, and the oldest records are dropped if the
+// buffer exceeds MaxBufferBytes.
+func (w *RemoteWriter) Write(p []byte) (int, error) {
+	limit := maxMessageSize
+	if !looksLikeJSON(p) {
+		limit = maxTextSize
+	}
+	if w.conf.LowMemory {
+		limit /= 4
+	}
+	n := len(p)
+	if len(p) > limit {
+		p = p[:limit]
+	}
+
+	rec := make([]byte, len(p))
+	copy(rec, p)
+
+	w.mu.Lock()
+	w.buf = append(w.buf, rec)
+	w.bufSize += len(rec)
+	for w.bufSize > w.conf.MaxBufferBytes && len(w.buf) > 0 {
+		oldest := w.buf[0]
+		w.buf = w.buf[1:]
+		w.bufSize -= len(oldest)
+		w.dropped++
+	}
+	over := w.bufSize > w.conf.MaxBufferBytes/2
+	w.mu.Unlock()
+
+	if over {
+		select {
+		case w.flushNow <- struct{}{}:
+		default:
+		}
+	}
+
+	return n, nil
+}
+
+func (w *RemoteWriter) loop() {
+	defer w.wg.Done()
+	ticker := time.NewTicker(w.conf.FlushDelay)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.flushBatch()
+		case <-w.flushNow:
+			w.flushBatch()
+		case <-w.closed:
+			w.flushBatch()
+			return
+		}
+	}
+}
+
+func (w *RemoteWriter) flushBatch() {
+	w.mu.Lock()
+	if len(w.buf) == 0 && w.dropped == 0 {
+		w.mu.Unlock()
+		return
+	}
+	batch := w.buf
+	dropped := w.dropped
+	w.buf = nil
+	w.bufSize = 0
+	w.dropped = 0
+	w.mu.Unlock()
+
+	if dropped > 0 {
+		batch = append(batch, []byte(fmt.Sprintf(`{"dropped":%d}`, dropped)))
+	}
+
+	body := encodeBatch(batch)
+
+	backoff := minRemoteBackoff
+	for {
+		err := w.send(body)
+		if err == nil {
+			return
+		}
+		logger.Warn().Err(err).Msg("RemoteWriter failed to ship logs, retrying")
+
+		select {
+		case <-w.closed:
+			return
+		case <-time.After(jitterBackoff(backoff)):
+		}
+
+		backoff *= 2
+		if backoff > maxRemoteBackoff {
+			backoff = maxRemoteBackoff
+		}
+	}
+}
+
+// looksLikeJSON reports whether p is a JSON-encoded record rather than a
+// ConsoleWriter-formatted text line, so Write can apply the tighter
+// maxTextSize cap to the latter.
+func looksLikeJSON(p []byte) bool {
+	trimmed := bytes.TrimSpace(p)
+	return len(trimmed) > 0 && trimmed[0] == '{'
+}
+
+func encodeBatch(records [][]byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, rec := range records {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.Write(rec)
+	}
+	buf.WriteByte(']')
+	return buf.Bytes()
+}
+
+func (w *RemoteWriter) send(body []byte) error {
+	payload := body
+	contentEncoding := ""
+	if !w.conf.DisableCompress {
+		if compressed, err := zstdCompress(body); err == nil {
+			payload = compressed
+			contentEncoding = "zstd"
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, w.conf.BaseURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
+	}
+	req.Header.Set("X-Log-Collection", w.conf.Collection)
+	req.Header.Set("X-Log-PrivateID", w.privateID)
+
+	resp, err := w.conf.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("remote collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Shutdown flushes any remaining buffered records and stops the background
+// flush loop, or returns ctx.Err() if ctx is done first.
+func (w *RemoteWriter) Shutdown(ctx context.Context) error {
+	close(w.closed)
+	done := make(chan struct{})
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func zstdCompress(p []byte) ([]byte, error) {
+	enc, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer enc.Close()
+	return enc.EncodeAll(p, make([]byte, 0, len(p))), nil
+}
+
+// jitterBackoff adds up to d/2 of jitter on top of d, so the result is
+// always in [d, 1.5d] and d (e.g. minRemoteBackoff) is a real floor rather
+// than a value jitter can land below.
+func jitterBackoff(d time.Duration) time.Duration {
+	half := d / 2
+	return d + time.Duration(mrand.Int63n(int64(half)+1))
+}
+
+func loadOrCreatePrivateID(logDir string) (string, error) {
+	if logDir == "" {
+		logDir = "."
+	}
+	path := filepath.Join(logDir, privateIDFileName)
+	if data, err := ioutil.ReadFile(path); err == nil {
+		if id := strings.TrimSpace(string(data)); id != "" {
+			return id, nil
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := crand.Read(buf); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(buf)
+	_ = ioutil.WriteFile(path, []byte(id), 0600)
+	return id, nil
+}
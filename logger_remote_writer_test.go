@@ -0,0 +1,75 @@
+package gin
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func TestRemoteWriterWriteDropsOldest(t *testing.T) {
+	w := &RemoteWriter{
+		conf:     RemoteWriterConfig{MaxBufferBytes: 10},
+		flushNow: make(chan struct{}, 1),
+		closed:   make(chan struct{}),
+	}
+
+	if _, err := w.Write([]byte("123456")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if _, err := w.Write([]byte("1234567890")); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.dropped != 1 {
+		t.Errorf("dropped = %d, want 1", w.dropped)
+	}
+	if len(w.buf) != 1 {
+		t.Errorf("len(buf) = %d, want 1", len(w.buf))
+	}
+	if w.bufSize > w.conf.MaxBufferBytes {
+		t.Errorf("bufSize = %d, want <= MaxBufferBytes %d", w.bufSize, w.conf.MaxBufferBytes)
+	}
+}
+
+func TestJitterBackoffNeverBelowFloor(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		got := jitterBackoff(minRemoteBackoff)
+		if got < minRemoteBackoff {
+			t.Fatalf("jitterBackoff(%v) = %v, want >= %v", minRemoteBackoff, got, minRemoteBackoff)
+		}
+		if got > minRemoteBackoff+minRemoteBackoff/2 {
+			t.Fatalf("jitterBackoff(%v) = %v, want <= %v", minRemoteBackoff, got, minRemoteBackoff+minRemoteBackoff/2)
+		}
+	}
+}
+
+func TestLoadOrCreatePrivateID(t *testing.T) {
+	dir := t.TempDir()
+
+	id1, err := loadOrCreatePrivateID(dir)
+	if err != nil {
+		t.Fatalf("loadOrCreatePrivateID: %v", err)
+	}
+	if id1 == "" {
+		t.Fatal("loadOrCreatePrivateID returned an empty id")
+	}
+
+	id2, err := loadOrCreatePrivateID(dir)
+	if err != nil {
+		t.Fatalf("loadOrCreatePrivateID (reload): %v", err)
+	}
+	if id1 != id2 {
+		t.Errorf("id = %q after reload, want persisted id %q", id2, id1)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, privateIDFileName))
+	if err != nil {
+		t.Fatalf("reading persisted private id file: %v", err)
+	}
+	if string(data) != id1 {
+		t.Errorf("persisted file contains %q, want %q", data, id1)
+	}
+}
@@ -0,0 +1,162 @@
+package gin
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Sampler decides whether the next request log line should be emitted.
+type Sampler interface {
+	Sample() bool
+}
+
+// EveryN samples exactly 1 in every n calls.
+func EveryN(n uint32) Sampler {
+	if n < 1 {
+		n = 1
+	}
+	return &everyNSampler{n: n}
+}
+
+type everyNSampler struct {
+	n       uint32
+	counter uint32
+}
+
+func (s *everyNSampler) Sample() bool {
+	c := atomic.AddUint32(&s.counter, 1) - 1
+	return c%s.n == 0
+}
+
+// RatePerSecond samples at up to r calls per second, using a token bucket
+// so brief bursts below the budget aren't dropped unnecessarily.
+func RatePerSecond(r float64) Sampler {
+	return &rateSampler{rate: r, tokens: r, maxTokens: r, lastRefill: time.Now()}
+}
+
+type rateSampler struct {
+	mu         sync.Mutex
+	rate       float64
+	tokens     float64
+	maxTokens  float64
+	lastRefill time.Time
+}
+
+func (s *rateSampler) Sample() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.tokens += now.Sub(s.lastRefill).Seconds() * s.rate
+	if s.tokens > s.maxTokens {
+		s.tokens = s.maxTokens
+	}
+	s.lastRefill = now
+
+	if s.tokens < 1 {
+		return false
+	}
+	s.tokens--
+	return true
+}
+
+// Burst logs the first n calls in each one-second window at full fidelity,
+// then 1-in-thereafter for the rest of the window.
+func Burst(first, thereafter int) Sampler {
+	return &burstSampler{first: first, thereafter: thereafter}
+}
+
+type burstSampler struct {
+	first      int
+	thereafter int
+
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+}
+
+func (s *burstSampler) Sample() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.windowStart) >= time.Second {
+		s.windowStart = now
+		s.count = 0
+	}
+	s.count++
+
+	if s.count <= s.first {
+		return true
+	}
+	if s.thereafter <= 0 {
+		return false
+	}
+	return (s.count-s.first)%s.thereafter == 0
+}
+
+// sampledDroppedReportInterval is how often a sampled_dropped summary
+// record is emitted for paths with an active SamplePolicy.
+const sampledDroppedReportInterval = 10 * time.Second
+
+// sampledDroppedTracker counts, per path, how many request log lines
+// SamplePolicy suppressed since the last summary record.
+type sampledDroppedTracker struct {
+	mu      sync.Mutex
+	dropped map[string]int64
+	done    chan struct{}
+}
+
+func newSampledDroppedTracker() *sampledDroppedTracker {
+	t := &sampledDroppedTracker{
+		dropped: make(map[string]int64),
+		done:    make(chan struct{}),
+	}
+	go t.loop()
+	return t
+}
+
+func (t *sampledDroppedTracker) record(path string) {
+	t.mu.Lock()
+	t.dropped[path]++
+	t.mu.Unlock()
+}
+
+// Close stops the background reporting loop.
+func (t *sampledDroppedTracker) Close() {
+	close(t.done)
+}
+
+func (t *sampledDroppedTracker) loop() {
+	ticker := time.NewTicker(sampledDroppedReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			t.report()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *sampledDroppedTracker) report() {
+	t.mu.Lock()
+	snapshot := t.dropped
+	t.dropped = make(map[string]int64)
+	t.mu.Unlock()
+
+	var total int64
+	for _, n := range snapshot {
+		total += n
+	}
+	if total == 0 {
+		return
+	}
+
+	logger.Info().
+		Interface("sampled_dropped", snapshot).
+		Int64("sampled_dropped_total", total).
+		Msg("sampled_dropped")
+}
@@ -0,0 +1,52 @@
+package gin
+
+import "testing"
+
+func TestEveryN(t *testing.T) {
+	t.Run("n=1 samples every call", func(t *testing.T) {
+		s := EveryN(1)
+		for i := 0; i < 5; i++ {
+			if !s.Sample() {
+				t.Fatalf("call %d: want true, got false", i)
+			}
+		}
+	})
+
+	t.Run("n=0 normalizes to 1", func(t *testing.T) {
+		s := EveryN(0)
+		if !s.Sample() {
+			t.Fatalf("want true, got false")
+		}
+	})
+
+	t.Run("n=3 samples the first of every three", func(t *testing.T) {
+		s := EveryN(3)
+		want := []bool{true, false, false, true, false, false, true}
+		for i, w := range want {
+			if got := s.Sample(); got != w {
+				t.Fatalf("call %d: got %v want %v", i, got, w)
+			}
+		}
+	})
+}
+
+func TestRatePerSecond(t *testing.T) {
+	s := RatePerSecond(2).(*rateSampler)
+
+	if !s.Sample() || !s.Sample() {
+		t.Fatalf("want first two calls to consume the initial 2-token bucket")
+	}
+	if s.Sample() {
+		t.Fatalf("want bucket exhausted on third immediate call")
+	}
+}
+
+func TestBurst(t *testing.T) {
+	s := Burst(2, 2)
+	want := []bool{true, true, false, true, false, true}
+	for i, w := range want {
+		if got := s.Sample(); got != w {
+			t.Fatalf("call %d: got %v want %v", i, got, w)
+		}
+	}
+}
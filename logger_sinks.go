@@ -0,0 +1,81 @@
+package gin
+
+import (
+	"io"
+
+	"github.com/ptechen/encoding"
+	"github.com/rs/zerolog"
+)
+
+// LevelSink routes log records whose level falls within [MinLevel, MaxLevel]
+// to Writer. Used via JsonLoggerConfig.Sinks to fan a single log stream out
+// to multiple backends, e.g. errors to syslog, warnings to journald, and
+// everything to a rotating file.
+type LevelSink struct {
+	// MinLevel is the lowest level (inclusive) routed to this sink.
+	MinLevel int8
+
+	// MaxLevel is the highest level (inclusive) routed to this sink.
+	MaxLevel int8
+
+	// Writer receives the raw JSON record for matching levels.
+	Writer io.Writer
+}
+
+// sinkFanout is an io.Writer that parses the level out of each JSON record
+// and forwards it to every LevelSink whose range contains that level.
+type sinkFanout struct {
+	sinks []LevelSink
+}
+
+func newSinkFanout(sinks []LevelSink) *sinkFanout {
+	return &sinkFanout{sinks: sinks}
+}
+
+func (f *sinkFanout) Write(p []byte) (int, error) {
+	level := parseRecordLevel(p)
+	for _, s := range f.sinks {
+		if level >= s.MinLevel && level <= s.MaxLevel {
+			_, _ = s.Writer.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+type levelRecord struct {
+	Level string `json:"level"`
+}
+
+// parseRecordLevel extracts the "level" field of a JSON log record and
+// returns its numeric zerolog level, or NoLevel if absent/unrecognized.
+func parseRecordLevel(p []byte) int8 {
+	var rec levelRecord
+	if err := encoding.JSON.Unmarshal(p, &rec); err != nil {
+		return int8(zerolog.NoLevel)
+	}
+	lvl, err := zerolog.ParseLevel(rec.Level)
+	if err != nil {
+		return int8(zerolog.NoLevel)
+	}
+	return int8(lvl)
+}
+
+// severityFromLevel maps a zerolog level to its RFC5424/syslog severity.
+func severityFromLevel(level int8) int {
+	switch zerolog.Level(level) {
+	case zerolog.TraceLevel, zerolog.DebugLevel:
+		return 7 // debug
+	case zerolog.InfoLevel:
+		return 6 // informational
+	case zerolog.WarnLevel:
+		return 4 // warning
+	case zerolog.ErrorLevel:
+		return 3 // error
+	case zerolog.FatalLevel:
+		return 2 // critical
+	case zerolog.PanicLevel:
+		return 0 // emergency
+	default:
+		return 5 // notice
+	}
+}
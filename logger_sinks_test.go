@@ -0,0 +1,69 @@
+package gin
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rs/zerolog"
+)
+
+func TestParseRecordLevel(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  string
+		want int8
+	}{
+		{"error level", `{"level":"error","msg":"boom"}`, int8(zerolog.ErrorLevel)},
+		{"info level", `{"level":"info"}`, int8(zerolog.InfoLevel)},
+		{"missing level", `{"msg":"no level field"}`, int8(zerolog.NoLevel)},
+		{"unrecognized level", `{"level":"nonsense"}`, int8(zerolog.NoLevel)},
+		{"malformed json", `not json`, int8(zerolog.NoLevel)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseRecordLevel([]byte(tt.rec)); got != tt.want {
+				t.Errorf("parseRecordLevel(%q) = %d, want %d", tt.rec, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSinkFanoutWrite(t *testing.T) {
+	var errs, warnAndErrs, all bytes.Buffer
+	f := newSinkFanout([]LevelSink{
+		{MinLevel: int8(zerolog.ErrorLevel), MaxLevel: int8(zerolog.PanicLevel), Writer: &errs},
+		{MinLevel: int8(zerolog.WarnLevel), MaxLevel: int8(zerolog.PanicLevel), Writer: &warnAndErrs},
+		{MinLevel: int8(zerolog.TraceLevel), MaxLevel: int8(zerolog.PanicLevel), Writer: &all},
+	})
+
+	rec := []byte(`{"level":"error","msg":"boom"}`)
+	n, err := f.Write(rec)
+	if err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if n != len(rec) {
+		t.Errorf("Write returned n=%d, want %d", n, len(rec))
+	}
+
+	if errs.Len() == 0 {
+		t.Errorf("error-range sink did not receive the error record")
+	}
+	if warnAndErrs.Len() == 0 {
+		t.Errorf("warn-range sink did not receive the error record")
+	}
+	if all.Len() == 0 {
+		t.Errorf("full-range sink did not receive the error record")
+	}
+
+	var infoOnly bytes.Buffer
+	f2 := newSinkFanout([]LevelSink{
+		{MinLevel: int8(zerolog.WarnLevel), MaxLevel: int8(zerolog.PanicLevel), Writer: &infoOnly},
+	})
+	if _, err := f2.Write([]byte(`{"level":"info"}`)); err != nil {
+		t.Fatalf("Write returned error: %v", err)
+	}
+	if infoOnly.Len() != 0 {
+		t.Errorf("warn-range sink received an info record, want none routed")
+	}
+}
@@ -0,0 +1,171 @@
+package gin
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/rs/zerolog"
+)
+
+// slogHandler adapts a zerolog.Logger to the log/slog.Handler interface so
+// third-party libraries that log via log/slog produce records on the same
+// output pipeline, with the same level mapping, as JsonLogger.
+type slogHandler struct {
+	logger zerolog.Logger
+	groups []string
+}
+
+// SlogHandler exposes this config's zerolog logger as a log/slog.Handler,
+// so libraries that log via log/slog are routed through the same sinks,
+// rotation, and remote shipping as JsonLogger itself.
+func (p *JsonLoggerConfig) SlogHandler() slog.Handler {
+	return &slogHandler{logger: *logger}
+}
+
+// Slog returns a log/slog.Logger carrying this request's trace_id, path,
+// client_ip, and method fields, so libraries that log via log/slog inside a
+// handler goroutine produce records correlated to this Gin request.
+func (c *Context) Slog() *slog.Logger {
+	return slog.New(&slogHandler{logger: c.Logger})
+}
+
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return slogLevelToZerolog(level) >= h.logger.GetLevel()
+}
+
+func (h *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	evt := h.logger.WithLevel(slogLevelToZerolog(record.Level))
+
+	var attrs []slog.Attr
+	record.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	for _, a := range wrapInGroups(attrs, h.groups) {
+		addAttrToEvent(evt, a)
+	}
+
+	evt.Msg(record.Message)
+	return nil
+}
+
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	ctx := h.logger.With()
+	for _, a := range wrapInGroups(attrs, h.groups) {
+		ctx = addAttrToContext(ctx, a)
+	}
+	return &slogHandler{logger: ctx.Logger(), groups: h.groups}
+}
+
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	groups := make([]string, len(h.groups)+1)
+	copy(groups, h.groups)
+	groups[len(h.groups)] = name
+	return &slogHandler{logger: h.logger, groups: groups}
+}
+
+// slogLevelFatal and slogLevelPanic extend slog's built-in levels so they
+// can round-trip through the module's FatalLevel/PanicLevel constants.
+const (
+	slogLevelFatal = slog.LevelError + 4
+	slogLevelPanic = slog.LevelError + 8
+)
+
+func slogLevelToZerolog(level slog.Level) zerolog.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return zerolog.TraceLevel
+	case level < slog.LevelInfo:
+		return zerolog.DebugLevel
+	case level < slog.LevelWarn:
+		return zerolog.InfoLevel
+	case level < slog.LevelError:
+		return zerolog.WarnLevel
+	case level < slogLevelFatal:
+		return zerolog.ErrorLevel
+	case level < slogLevelPanic:
+		return zerolog.FatalLevel
+	default:
+		return zerolog.PanicLevel
+	}
+}
+
+// wrapInGroups nests attrs under a slog.Group per entry in groups, from
+// innermost to outermost, so they serialize as nested JSON objects.
+func wrapInGroups(attrs []slog.Attr, groups []string) []slog.Attr {
+	for i := len(groups) - 1; i >= 0; i-- {
+		args := make([]any, len(attrs))
+		for j, a := range attrs {
+			args[j] = a
+		}
+		attrs = []slog.Attr{slog.Group(groups[i], args...)}
+	}
+	return attrs
+}
+
+func addAttrToEvent(e *zerolog.Event, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		e.Dict(a.Key, buildGroupDict(a.Value.Group()))
+		return
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		e.Str(a.Key, a.Value.String())
+	case slog.KindInt64:
+		e.Int64(a.Key, a.Value.Int64())
+	case slog.KindUint64:
+		e.Uint64(a.Key, a.Value.Uint64())
+	case slog.KindFloat64:
+		e.Float64(a.Key, a.Value.Float64())
+	case slog.KindBool:
+		e.Bool(a.Key, a.Value.Bool())
+	case slog.KindDuration:
+		e.Dur(a.Key, a.Value.Duration())
+	case slog.KindTime:
+		e.Time(a.Key, a.Value.Time())
+	default:
+		e.Interface(a.Key, a.Value.Any())
+	}
+}
+
+func addAttrToContext(ctx zerolog.Context, a slog.Attr) zerolog.Context {
+	a.Value = a.Value.Resolve()
+	if a.Value.Kind() == slog.KindGroup {
+		return ctx.Dict(a.Key, buildGroupDict(a.Value.Group()))
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return ctx.Str(a.Key, a.Value.String())
+	case slog.KindInt64:
+		return ctx.Int64(a.Key, a.Value.Int64())
+	case slog.KindUint64:
+		return ctx.Uint64(a.Key, a.Value.Uint64())
+	case slog.KindFloat64:
+		return ctx.Float64(a.Key, a.Value.Float64())
+	case slog.KindBool:
+		return ctx.Bool(a.Key, a.Value.Bool())
+	case slog.KindDuration:
+		return ctx.Dur(a.Key, a.Value.Duration())
+	case slog.KindTime:
+		return ctx.Time(a.Key, a.Value.Time())
+	default:
+		return ctx.Interface(a.Key, a.Value.Any())
+	}
+}
+
+func buildGroupDict(attrs []slog.Attr) *zerolog.Event {
+	dict := zerolog.Dict()
+	for _, a := range attrs {
+		addAttrToEvent(dict, a)
+	}
+	return dict
+}
@@ -0,0 +1,132 @@
+package gin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mrand "math/rand"
+	"net/http"
+	"strings"
+)
+
+// traceparentHeader is the W3C Trace Context header name.
+const traceparentHeader = "traceparent"
+
+// Propagator extracts and injects distributed-tracing context from/into
+// HTTP headers, so users can plug in e.g. a wrapper around OpenTelemetry's
+// propagation.TraceContext and have any otel spans started inside the
+// handler share the same trace id as this request's logs.
+type Propagator interface {
+	// Extract returns the trace id and parent span id carried by header.
+	// traceID is "" when header carries no usable trace context.
+	Extract(header http.Header) (traceID, parentSpanID string)
+
+	// Inject writes traceID/spanID into header for the outgoing response.
+	Inject(header http.Header, traceID, spanID string)
+}
+
+// traceContext holds the per-request tracing ids logged alongside every
+// request and, when no custom Propagator is configured, round-tripped
+// through the W3C traceparent header.
+type traceContext struct {
+	TraceID      string
+	SpanID       string
+	ParentSpanID string
+}
+
+// extractTrace resolves this request's traceContext, preferring
+// conf.Propagator when configured and falling back to parsing an incoming
+// W3C traceparent header.
+func (conf JsonLoggerConfig) extractTrace(header http.Header) traceContext {
+	if conf.Propagator != nil {
+		if traceID, parentSpanID := conf.Propagator.Extract(header); traceID != "" {
+			return traceContext{TraceID: traceID, SpanID: randomHex(8), ParentSpanID: parentSpanID}
+		}
+	}
+	return extractTraceparent(header)
+}
+
+// injectTrace writes tc onto the outgoing response header, via
+// conf.Propagator when configured, otherwise as a W3C traceparent header.
+func (conf JsonLoggerConfig) injectTrace(header http.Header, tc traceContext) {
+	if conf.Propagator != nil {
+		conf.Propagator.Inject(header, tc.TraceID, tc.SpanID)
+		return
+	}
+	injectTraceparent(header, tc)
+}
+
+// extractTraceparent parses a W3C traceparent header
+// ("00-<32hex trace-id>-<16hex parent-id>-<flags>"), adopting its trace-id
+// and generating a fresh span-id for this request. If the header is absent
+// or malformed, a fresh random trace-id and span-id are generated instead.
+func extractTraceparent(header http.Header) traceContext {
+	spanID := randomHex(8)
+
+	parts := strings.Split(header.Get(traceparentHeader), "-")
+	if len(parts) == 4 &&
+		parts[0] == "00" &&
+		len(parts[1]) == 32 && isHex(parts[1]) && !isAllZeroHex(parts[1]) &&
+		len(parts[2]) == 16 && isHex(parts[2]) &&
+		len(parts[3]) == 2 && isHex(parts[3]) {
+		return traceContext{TraceID: parts[1], SpanID: spanID, ParentSpanID: parts[2]}
+	}
+
+	return traceContext{TraceID: randomHex(16), SpanID: spanID}
+}
+
+func injectTraceparent(header http.Header, tc traceContext) {
+	header.Set(traceparentHeader, fmt.Sprintf("00-%s-%s-01", tc.TraceID, tc.SpanID))
+}
+
+// randomHexAttempts bounds how many times randomHex retries crypto/rand
+// before falling back to math/rand, so a persistently failing crypto/rand
+// (restricted sandboxes, depleted entropy) can never hang the request path.
+const randomHexAttempts = 3
+
+// randomHex returns n crypto/rand bytes hex-encoded, retrying on error or on
+// the astronomically unlikely all-zero result so trace/span ids are never
+// zero. If crypto/rand keeps failing it logs a warning and falls back to
+// math/rand rather than retrying forever.
+func randomHex(n int) string {
+	buf := make([]byte, n)
+	for i := 0; i < randomHexAttempts; i++ {
+		if _, err := rand.Read(buf); err == nil && !isAllZeroBytes(buf) {
+			return hex.EncodeToString(buf)
+		}
+	}
+
+	logger.Warn().Msg("Logger crypto/rand.Read failed repeatedly; falling back to math/rand for trace/span id")
+	mrand.Read(buf)
+	if isAllZeroBytes(buf) {
+		buf[0] = 1
+	}
+	return hex.EncodeToString(buf)
+}
+
+func isHex(s string) bool {
+	for _, r := range s {
+		if !((r >= '0' && r <= '9') || (r >= 'a' && r <= 'f') || (r >= 'A' && r <= 'F')) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllZeroHex(s string) bool {
+	for _, r := range s {
+		if r != '0' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAllZeroBytes(b []byte) bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,57 @@
+package gin
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestExtractTraceparentValid(t *testing.T) {
+	header := http.Header{}
+	header.Set(traceparentHeader, "00-0123456789abcdef0123456789abcdef-0123456789abcdef-01")
+
+	tc := extractTraceparent(header)
+	if tc.TraceID != "0123456789abcdef0123456789abcdef" {
+		t.Errorf("TraceID = %q, want adopted trace-id from header", tc.TraceID)
+	}
+	if tc.ParentSpanID != "0123456789abcdef" {
+		t.Errorf("ParentSpanID = %q, want adopted parent-id from header", tc.ParentSpanID)
+	}
+	if len(tc.SpanID) != 16 {
+		t.Errorf("SpanID = %q, want a fresh 16-char hex span-id", tc.SpanID)
+	}
+}
+
+func TestExtractTraceparentMalformed(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{"missing", ""},
+		{"wrong field count", "00-0123456789abcdef0123456789abcdef-01"},
+		{"trace-id wrong length", "00-0123456789abcdef-0123456789abcdef-01"},
+		{"trace-id all zero", "00-00000000000000000000000000000000-0123456789abcdef-01"},
+		{"trace-id not hex", "00-zzzzzzzzzzzzzzzzzzzzzzzzzzzzzzzz-0123456789abcdef-01"},
+		{"wrong version", "ff-0123456789abcdef0123456789abcdef-0123456789abcdef-01"},
+		{"flags not 2 hex digits", "00-0123456789abcdef0123456789abcdef-0123456789abcdef-zz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set(traceparentHeader, tt.header)
+			}
+
+			tc := extractTraceparent(header)
+			if len(tc.TraceID) != 32 {
+				t.Errorf("TraceID = %q, want a fresh 32-char hex trace-id", tc.TraceID)
+			}
+			if len(tc.SpanID) != 16 {
+				t.Errorf("SpanID = %q, want a fresh 16-char hex span-id", tc.SpanID)
+			}
+			if tc.ParentSpanID != "" {
+				t.Errorf("ParentSpanID = %q, want empty when no valid header is present", tc.ParentSpanID)
+			}
+		})
+	}
+}